@@ -0,0 +1,55 @@
+// Command go-mapnik-server is a drop-in renderd/mod_tile-style HTTP tile
+// server: point it at a Mapnik stylesheet and it serves
+// /{z}/{x}/{y}.{format} tiles (plus @2x retina tiles) straight out of the
+// box, backed by an on-disk cache when -cache-dir is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/herryhou/go-mapnik/tileserver"
+)
+
+func main() {
+	stylesheet := flag.String("stylesheet", "", "path to the Mapnik XML stylesheet")
+	addr := flag.String("listen", ":8080", "address to listen on")
+	tileSize := flag.Uint("tile-size", 256, "tile width/height in pixels")
+	poolSize := flag.Int("pool-size", 4, "number of concurrently rendering maps")
+	cacheDir := flag.String("cache-dir", "", "directory for cached tiles (in-memory cache if empty)")
+	flag.Parse()
+
+	if *stylesheet == "" {
+		log.Fatal("go-mapnik-server: -stylesheet is required")
+	}
+
+	pool, err := tileserver.NewMapPool(*poolSize, *stylesheet, uint32(*tileSize), uint32(*tileSize))
+	if err != nil {
+		log.Fatalf("go-mapnik-server: %s", err)
+	}
+
+	var cache tileserver.Cache
+	if *cacheDir != "" {
+		cache = &tileserver.FileCache{Dir: *cacheDir}
+	} else {
+		cache = tileserver.NewMemoryCache(1024)
+	}
+
+	handler := &tileserver.Handler{Pool: pool, Cache: cache, StyleKey: styleKey(*stylesheet)}
+	log.Printf("go-mapnik-server: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, handler))
+}
+
+// styleKey identifies the stylesheet at path for cache invalidation,
+// combining its path with its modification time so reloading an edited
+// stylesheet doesn't serve tiles rendered from the old one.
+func styleKey(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return path
+	}
+	return fmt.Sprintf("%s@%d", path, info.ModTime().UnixNano())
+}
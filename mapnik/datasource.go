@@ -0,0 +1,120 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// datasourceHandle is implemented by Datasource and MemoryDatasource, the
+// two ways of attaching feature data to a Layer.
+type datasourceHandle interface {
+	datasource() *C.struct__mapnik_datasource_t
+}
+
+// Datasource wraps a Mapnik datasource plugin instance (shape, postgis,
+// geojson, csv, ...), the source a Layer reads its features from.
+type Datasource struct {
+	ds *C.struct__mapnik_datasource_t
+}
+
+func (d *Datasource) datasource() *C.struct__mapnik_datasource_t {
+	return d.ds
+}
+
+// Free releases the datasource's underlying resources.
+func (d *Datasource) Free() {
+	C.mapnik_datasource_free(d.ds)
+	d.ds = nil
+}
+
+func newDatasource(params map[string]string) (*Datasource, error) {
+	p := C.mapnik_parameters()
+	defer C.mapnik_parameters_free(p)
+	for k, v := range params {
+		if v == "" {
+			continue
+		}
+		ck := C.CString(k)
+		cv := C.CString(v)
+		C.mapnik_parameters_set(p, ck, cv)
+		C.free(unsafe.Pointer(ck))
+		C.free(unsafe.Pointer(cv))
+	}
+	ds := C.mapnik_datasource(p)
+	if ds == nil {
+		return nil, errors.New("mapnik: failed to create " + params["type"] + " datasource")
+	}
+	return &Datasource{ds: ds}, nil
+}
+
+// Shapefile opens an ESRI Shapefile as a Datasource.
+func Shapefile(path string) (*Datasource, error) {
+	return newDatasource(map[string]string{"type": "shape", "file": path})
+}
+
+// GeoJSON opens a GeoJSON file as a Datasource.
+func GeoJSON(path string) (*Datasource, error) {
+	return newDatasource(map[string]string{"type": "geojson", "file": path})
+}
+
+// CSV opens a delimited text file as a Datasource, using Mapnik's CSV
+// plugin to infer geometry from WKT/lon-lat columns.
+func CSV(path string) (*Datasource, error) {
+	return newDatasource(map[string]string{"type": "csv", "file": path})
+}
+
+// PostGISOptions configures a PostGIS Datasource.
+type PostGISOptions struct {
+	Host, Port, DBName, User, Password string
+	// Table is a table name, or a subquery wrapped in parentheses and
+	// aliased, as accepted by Mapnik's postgis plugin.
+	Table string
+	// GeometryField is not passed to Mapnik if empty, leaving the choice
+	// of geometry column to the postgis plugin.
+	GeometryField string
+}
+
+// PostGIS opens a PostGIS table or query as a Datasource.
+func PostGIS(opts PostGISOptions) (*Datasource, error) {
+	return newDatasource(map[string]string{
+		"type":           "postgis",
+		"host":           opts.Host,
+		"port":           opts.Port,
+		"dbname":         opts.DBName,
+		"user":           opts.User,
+		"password":       opts.Password,
+		"table":          opts.Table,
+		"geometry_field": opts.GeometryField,
+	})
+}
+
+// MemoryDatasource is an in-memory Datasource built from Features added at
+// runtime with AddFeature, useful for rendering ad-hoc feature collections
+// (e.g. a one-off tile overlay) without writing them to a file or database.
+type MemoryDatasource struct {
+	ds *C.struct__mapnik_datasource_t
+}
+
+// NewMemoryDatasource creates an empty MemoryDatasource.
+func NewMemoryDatasource() *MemoryDatasource {
+	return &MemoryDatasource{ds: C.mapnik_memory_datasource()}
+}
+
+func (d *MemoryDatasource) datasource() *C.struct__mapnik_datasource_t {
+	return d.ds
+}
+
+// AddFeature adds f to the datasource.
+func (d *MemoryDatasource) AddFeature(f *Feature) {
+	C.mapnik_memory_datasource_add_feature(d.ds, f.f)
+}
+
+// Free releases the datasource's underlying resources.
+func (d *MemoryDatasource) Free() {
+	C.mapnik_datasource_free(d.ds)
+	d.ds = nil
+}
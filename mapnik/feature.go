@@ -0,0 +1,94 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Context declares the attribute fields that Features created from it will
+// carry, mirroring mapnik::context_type.
+type Context struct {
+	c *C.struct__mapnik_context_t
+}
+
+// NewContext creates an empty attribute Context.
+func NewContext() *Context {
+	return &Context{c: C.mapnik_context()}
+}
+
+// AddField declares an attribute field name. Fields must be declared before
+// creating Features from this Context.
+func (c *Context) AddField(name string) {
+	cs := C.CString(name)
+	defer C.free(unsafe.Pointer(cs))
+	C.mapnik_context_add_field(c.c, cs)
+}
+
+// Free releases the context's underlying resources.
+func (c *Context) Free() {
+	C.mapnik_context_free(c.c)
+	c.c = nil
+}
+
+// Feature is a single geometry plus its attributes, as added to a
+// MemoryDatasource for ad-hoc rendering.
+type Feature struct {
+	f *C.struct__mapnik_feature_t
+}
+
+// NewFeature creates a Feature with the given id, using ctx to declare the
+// attribute fields it can carry.
+func NewFeature(id uint64, ctx *Context) *Feature {
+	return &Feature{f: C.mapnik_feature(C.ulonglong(id), ctx.c)}
+}
+
+// Free releases the feature's underlying resources. Not needed for
+// features that were added to a MemoryDatasource, which takes ownership of
+// them.
+func (f *Feature) Free() {
+	C.mapnik_feature_free(f.f)
+	f.f = nil
+}
+
+// AddGeometryFromWKT parses wkt and adds it to the feature's geometry.
+func (f *Feature) AddGeometryFromWKT(wkt string) error {
+	cs := C.CString(wkt)
+	defer C.free(unsafe.Pointer(cs))
+	if C.mapnik_feature_add_geometry_wkt(f.f, cs) != 0 {
+		return errors.New("mapnik: failed to parse WKT geometry")
+	}
+	return nil
+}
+
+// AddGeometryFromWKB parses wkb and adds it to the feature's geometry.
+func (f *Feature) AddGeometryFromWKB(wkb []byte) error {
+	if len(wkb) == 0 {
+		return errors.New("mapnik: empty WKB geometry")
+	}
+	if C.mapnik_feature_add_geometry_wkb(f.f, (*C.char)(unsafe.Pointer(&wkb[0])), C.size_t(len(wkb))) != 0 {
+		return errors.New("mapnik: failed to parse WKB geometry")
+	}
+	return nil
+}
+
+// SetProperty sets the string-valued attribute name, which must have been
+// declared on the Feature's Context via AddField.
+func (f *Feature) SetProperty(name, value string) {
+	cname := C.CString(name)
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cname))
+	defer C.free(unsafe.Pointer(cvalue))
+	C.mapnik_feature_add_text_property(f.f, cname, cvalue)
+}
+
+// SetNumericProperty sets the float64-valued attribute name, which must
+// have been declared on the Feature's Context via AddField.
+func (f *Feature) SetNumericProperty(name string, value float64) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	C.mapnik_feature_add_double_property(f.f, cname, C.double(value))
+}
@@ -0,0 +1,55 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import "unsafe"
+
+// Layer wraps a Mapnik map layer: a Datasource plus the set of style rules
+// and spatial reference it's rendered with.
+type Layer struct {
+	l *C.struct__mapnik_layer_t
+}
+
+// NewLayer creates a layer with the given name, ready to receive a
+// Datasource, styles and SRS before being added to a Map with AddLayer.
+func NewLayer(name string) *Layer {
+	cs := C.CString(name)
+	defer C.free(unsafe.Pointer(cs))
+	return &Layer{l: C.mapnik_layer(cs)}
+}
+
+// Free releases the layer's underlying resources. Not needed for layers
+// that were added to a Map, which takes ownership of them.
+func (l *Layer) Free() {
+	C.mapnik_layer_free(l.l)
+	l.l = nil
+}
+
+// SetDatasource attaches a Datasource or MemoryDatasource to the layer.
+func (l *Layer) SetDatasource(ds datasourceHandle) {
+	C.mapnik_layer_set_datasource(l.l, ds.datasource())
+}
+
+// SetStyles sets the names of the stylesheet rules applied to this layer.
+func (l *Layer) SetStyles(styles ...string) {
+	for _, s := range styles {
+		cs := C.CString(s)
+		C.mapnik_layer_add_style(l.l, cs)
+		C.free(unsafe.Pointer(cs))
+	}
+}
+
+// SetSRS sets the layer's spatial reference system, e.g.
+// "+proj=longlat +datum=WGS84 +no_defs".
+func (l *Layer) SetSRS(srs string) {
+	cs := C.CString(srs)
+	defer C.free(unsafe.Pointer(cs))
+	C.mapnik_layer_set_srs(l.l, cs)
+}
+
+// AddLayer adds l to the map's layer stack.
+func (m *Map) AddLayer(l *Layer) {
+	C.mapnik_map_add_layer(m.m, l.l)
+}
@@ -59,10 +59,14 @@ func (p Projection) Forward(coord Coord) Coord {
 // Map base type
 type Map struct {
 	m *C.struct__mapnik_map_t
+
+	// attribution caches the "attribution" stylesheet parameter, see
+	// (*Map).Attribution.
+	attribution *string
 }
 
 func NewMap(width, height uint32) *Map {
-	return &Map{C.mapnik_map(C.uint(width), C.uint(height))}
+	return &Map{m: C.mapnik_map(C.uint(width), C.uint(height))}
 }
 
 func (m *Map) lastError() error {
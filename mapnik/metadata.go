@@ -0,0 +1,81 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+// Parameters returns the stylesheet's extra <Parameter> entries from its
+// <Map> element, e.g. attribution or other TileJSON-style metadata set by
+// the cartographer.
+func (m *Map) Parameters() map[string]string {
+	params := C.mapnik_map_get_extra_parameters(m.m)
+	defer C.mapnik_parameters_free(params)
+
+	n := int(C.mapnik_parameters_count(params))
+	result := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		k := C.mapnik_parameters_get_key(params, C.int(i))
+		v := C.mapnik_parameters_get_value(params, C.int(i))
+		result[C.GoString(k)] = C.GoString(v)
+	}
+	return result
+}
+
+// Attribution returns the stylesheet's "attribution" parameter, if set.
+// The value is read once, on first access, and cached; a later Load or
+// LoadString does not refresh it.
+func (m *Map) Attribution() string {
+	if m.attribution == nil {
+		a := m.Parameters()["attribution"]
+		m.attribution = &a
+	}
+	return *m.attribution
+}
+
+// MaximumExtent returns the stylesheet's declared maximum extent, if any.
+// Tile servers use this to avoid rendering outside the area a style was
+// designed for.
+func (m *Map) MaximumExtent() (minx, miny, maxx, maxy float64, ok bool) {
+	bbox := C.mapnik_map_get_maximum_extent(m.m)
+	if bbox == nil {
+		return 0, 0, 0, 0, false
+	}
+	defer C.mapnik_bbox_free(bbox)
+	return float64(C.mapnik_bbox_minx(bbox)), float64(C.mapnik_bbox_miny(bbox)),
+		float64(C.mapnik_bbox_maxx(bbox)), float64(C.mapnik_bbox_maxy(bbox)), true
+}
+
+// BufferSize returns the map's buffer size in pixels, as set by
+// SetBufferSize or declared in the stylesheet.
+func (m *Map) BufferSize() int {
+	return int(C.mapnik_map_get_buffer_size(m.m))
+}
+
+// LayerInfo describes a single layer of a loaded stylesheet, as returned
+// by (*Map).Layers.
+type LayerInfo struct {
+	Name    string
+	SRS     string
+	Active  bool
+	MinZoom float64
+	MaxZoom float64
+}
+
+// Layers returns metadata for every layer declared in the stylesheet, in
+// stylesheet order. Tile servers use this to populate TileJSON responses
+// and to skip render calls outside a layer's declared scale range.
+func (m *Map) Layers() []LayerInfo {
+	n := int(C.mapnik_map_layer_count(m.m))
+	layers := make([]LayerInfo, n)
+	for i := 0; i < n; i++ {
+		l := C.mapnik_map_get_layer(m.m, C.size_t(i))
+		layers[i] = LayerInfo{
+			Name:    C.GoString(C.mapnik_layer_get_name(l)),
+			SRS:     C.GoString(C.mapnik_layer_get_srs(l)),
+			Active:  C.mapnik_layer_is_active(l) != 0,
+			MinZoom: float64(C.mapnik_layer_get_min_zoom(l)),
+			MaxZoom: float64(C.mapnik_layer_get_max_zoom(l)),
+		}
+	}
+	return layers
+}
@@ -0,0 +1,101 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import (
+	"errors"
+	"math"
+	"unsafe"
+)
+
+// earthCircumference is the circumference of the Web Mercator (EPSG:3857)
+// projection of the earth, in projected meters.
+const earthCircumference = 2 * math.Pi * 6378137.0
+
+// VectorTile holds a Mapbox Vector Tile (MVT): protobuf-encoded, tile-clipped
+// geometry. (*Map).CreateMVT and (*Map).RenderMVT do not use this type; they
+// return and accept the same payload as a plain []byte.
+type VectorTile struct {
+	data []byte
+}
+
+// Bytes returns the raw protobuf-encoded MVT payload.
+func (t *VectorTile) Bytes() []byte {
+	return t.data
+}
+
+// XYZToMerc computes the Web Mercator bounding box (minx, miny, maxx, maxy)
+// of the standard slippy-map tile (x, y, z), so callers don't have to derive
+// it themselves before calling CreateMVT or ZoomToMinMax.
+func XYZToMerc(x, y, z int) (minx, miny, maxx, maxy float64) {
+	n := math.Exp2(float64(z))
+	tileSize := earthCircumference / n
+	minx = -earthCircumference/2 + float64(x)*tileSize
+	maxx = minx + tileSize
+	maxy = earthCircumference/2 - float64(y)*tileSize
+	miny = maxy - tileSize
+	return
+}
+
+// CreateMVT zooms the map to the Web Mercator extent of the slippy-map tile
+// (x, y, z) and encodes its layers as a Mapbox Vector Tile. This is the
+// (typically Postgres/DB-bound) half of the tile workflow; pair it with
+// RenderMVT to do the cheap, cacheable styling step separately.
+func (m *Map) CreateMVT(x, y, z int) ([]byte, error) {
+	minx, miny, maxx, maxy := XYZToMerc(x, y, z)
+	m.ZoomToMinMax(minx, miny, maxx, maxy)
+
+	vt := C.mapnik_vector_tile_create(m.m, C.int(x), C.int(y), C.int(z))
+	if vt == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_vector_tile_free(vt)
+
+	b := C.mapnik_vector_tile_to_blob(vt)
+	defer C.mapnik_vector_tile_blob_free(b)
+	return C.GoBytes(unsafe.Pointer(b.ptr), C.int(b.len)), nil
+}
+
+// RenderMVT rasterizes an already-encoded Mapbox Vector Tile through the
+// map's stylesheet, returning an encoded image blob in opts.Format (see
+// Render). Unlike CreateMVT it does no database work, so results are cheap
+// to produce and safe to cache. A parsed MVT only carries geometry in
+// tile-local coordinates, so x, y, z (the same slippy-map tile CreateMVT
+// was called with) are required to zoom the map to the right Web Mercator
+// extent before rendering.
+func (m *Map) RenderMVT(tile []byte, x, y, z int, opts RenderOpts) ([]byte, error) {
+	if len(tile) == 0 {
+		return nil, errors.New("mapnik: empty vector tile")
+	}
+
+	minx, miny, maxx, maxy := XYZToMerc(x, y, z)
+	m.ZoomToMinMax(minx, miny, maxx, maxy)
+
+	vt := C.mapnik_vector_tile_parse((*C.char)(unsafe.Pointer(&tile[0])), C.size_t(len(tile)))
+	if vt == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_vector_tile_free(vt)
+
+	scaleFactor := opts.ScaleFactor
+	if scaleFactor == 0.0 {
+		scaleFactor = 1.0
+	}
+	i := C.mapnik_vector_tile_render(vt, m.m, C.double(opts.Scale), C.double(scaleFactor))
+	if i == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_image_free(i)
+
+	format := opts.Format
+	if format == "" {
+		format = "png8"
+	}
+	cformat := C.CString(format)
+	defer C.free(unsafe.Pointer(cformat))
+	b := C.mapnik_image_to_blob(i, cformat)
+	defer C.mapnik_image_blob_free(b)
+	return C.GoBytes(unsafe.Pointer(b.ptr), C.int(b.len)), nil
+}
@@ -0,0 +1,73 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import (
+	"image"
+	"unsafe"
+)
+
+// Render rasterizes the map and returns it encoded as opts.Format (e.g.
+// "png256", "jpeg80", "webp"). The special format "raw" skips encoding
+// entirely and returns the uncompressed RGBA pixel buffer, width*height*4
+// bytes long; use RenderImage if you want that buffer as an image.Image.
+func (m *Map) Render(opts RenderOpts) ([]byte, error) {
+	scaleFactor := opts.ScaleFactor
+	if scaleFactor == 0.0 {
+		scaleFactor = 1.0
+	}
+	i := C.mapnik_map_render_to_image(m.m, C.double(opts.Scale), C.double(scaleFactor))
+	if i == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_image_free(i)
+
+	if opts.Format == "raw" {
+		return rawImageBytes(i), nil
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = "png8"
+	}
+	cformat := C.CString(format)
+	defer C.free(unsafe.Pointer(cformat))
+	b := C.mapnik_image_to_blob(i, cformat)
+	defer C.mapnik_image_blob_free(b)
+	return C.GoBytes(unsafe.Pointer(b.ptr), C.int(b.len)), nil
+}
+
+// RenderImage rasterizes the map and returns it as a stdlib image.Image,
+// letting callers composite tiles with image/draw or re-encode with
+// image/jpeg, image/gif, etc. without a PNG round-trip.
+func (m *Map) RenderImage(opts RenderOpts) (*image.NRGBA, error) {
+	scaleFactor := opts.ScaleFactor
+	if scaleFactor == 0.0 {
+		scaleFactor = 1.0
+	}
+	i := C.mapnik_map_render_to_image(m.m, C.double(opts.Scale), C.double(scaleFactor))
+	if i == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_image_free(i)
+
+	width := int(C.mapnik_image_width(i))
+	height := int(C.mapnik_image_height(i))
+	pix := rawImageBytes(i)
+
+	return &image.NRGBA{
+		Pix:    pix,
+		Stride: width * 4,
+		Rect:   image.Rect(0, 0, width, height),
+	}, nil
+}
+
+// rawImageBytes copies the raw, uncompressed RGBA pixel buffer out of a
+// mapnik image handle.
+func rawImageBytes(i *C.struct__mapnik_image_t) []byte {
+	raw := C.mapnik_image_to_raw(i)
+	defer C.mapnik_image_raw_free(raw)
+	return C.GoBytes(unsafe.Pointer(raw.ptr), C.int(raw.len))
+}
@@ -0,0 +1,80 @@
+package mapnik
+
+// #include <stdlib.h>
+// #include "mapnik_c_api.h"
+import "C"
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// ErrNoCairoSupport is returned by RenderToVectorFile and
+// RenderToVectorMemory when the underlying Mapnik library was built
+// without Cairo support.
+var ErrNoCairoSupport = errors.New("mapnik: Cairo support not available")
+
+// RenderToVectorFile renders the map to path using Mapnik's Cairo backend,
+// producing PDF, SVG or PostScript output suited for cartographic
+// printing. The format is taken from opts.Format if set ("pdf", "svg",
+// "ps"), otherwise inferred from path's extension.
+func (m *Map) RenderToVectorFile(path string, opts RenderOpts) error {
+	if C.mapnik_has_cairo_support() == 0 {
+		return ErrNoCairoSupport
+	}
+	scaleFactor := opts.ScaleFactor
+	if scaleFactor == 0.0 {
+		scaleFactor = 1.0
+	}
+	format := opts.Format
+	if format == "" {
+		format = vectorFormatFromExt(path)
+	}
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	cformat := C.CString(format)
+	defer C.free(unsafe.Pointer(cformat))
+	if C.mapnik_map_render_to_cairo_file(m.m, cpath, cformat, C.double(opts.Scale), C.double(scaleFactor)) != 0 {
+		return m.lastError()
+	}
+	return nil
+}
+
+// RenderToVectorMemory is RenderToVectorFile without touching disk: it
+// returns the encoded PDF/SVG/PostScript document as a byte slice.
+// opts.Format is required since there's no path to infer it from.
+func (m *Map) RenderToVectorMemory(opts RenderOpts) ([]byte, error) {
+	if C.mapnik_has_cairo_support() == 0 {
+		return nil, ErrNoCairoSupport
+	}
+	if opts.Format == "" {
+		return nil, errors.New("mapnik: RenderToVectorMemory requires opts.Format")
+	}
+	scaleFactor := opts.ScaleFactor
+	if scaleFactor == 0.0 {
+		scaleFactor = 1.0
+	}
+	cformat := C.CString(opts.Format)
+	defer C.free(unsafe.Pointer(cformat))
+	b := C.mapnik_map_render_to_cairo_blob(m.m, cformat, C.double(opts.Scale), C.double(scaleFactor))
+	if b.ptr == nil {
+		return nil, m.lastError()
+	}
+	defer C.mapnik_image_blob_free(b)
+	return C.GoBytes(unsafe.Pointer(b.ptr), C.int(b.len)), nil
+}
+
+// vectorFormatFromExt maps a file extension to the Cairo surface format
+// name Mapnik expects.
+func vectorFormatFromExt(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return "svg"
+	case ".ps":
+		return "ps"
+	default:
+		return "pdf"
+	}
+}
@@ -0,0 +1,116 @@
+package tileserver
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores rendered tile blobs keyed by CacheKey.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte)
+}
+
+// CacheKey derives a cache key from a style identifier (see
+// Handler.StyleKey) and the tile coordinate and rendering parameters that
+// affect its output, so distinct styles, scale factors and formats never
+// collide, even when they share a Cache.
+func CacheKey(style string, x, y, z int, scaleFactor float64, format string) string {
+	return fmt.Sprintf("%s/%d/%d/%d@%gx.%s", style, z, x, y, scaleFactor, format)
+}
+
+// MemoryCache is an in-memory, fixed-capacity LRU Cache.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewMemoryCache returns a MemoryCache that keeps at most capacity tiles,
+// evicting the least recently used entry once it's full.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).data, true
+}
+
+func (c *MemoryCache) Set(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[key]; ok {
+		c.ll.MoveToFront(e)
+		e.Value.(*memoryCacheEntry).data = data
+		return
+	}
+	e := c.ll.PushFront(&memoryCacheEntry{key: key, data: data})
+	c.items[key] = e
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
+
+// FileCache stores tiles under Dir, one file per key. Entries older than
+// TTL are treated as a miss and re-rendered; a zero TTL means entries never
+// expire (the "ForceCache" behavior of always trusting what's on disk).
+type FileCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.TTL > 0 && time.Since(info.ModTime()) > c.TTL {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *FileCache) Set(key string, data []byte) {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return
+	}
+	ioutil.WriteFile(c.path(key), data, 0644)
+}
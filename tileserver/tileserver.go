@@ -0,0 +1,152 @@
+// Package tileserver implements an XYZ slippy-map tile HTTP server on top
+// of a *mapnik.Map, in the spirit of renderd/mod_tile: a MapPool hands
+// out pre-loaded maps to concurrent requests (a mapnik.Map is not
+// thread-safe, so it cannot be shared across goroutines), and a pluggable
+// Cache avoids re-rendering tiles that were already served.
+package tileserver
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/herryhou/go-mapnik/mapnik"
+)
+
+// MapPool maintains a fixed number of pre-loaded *mapnik.Map instances and
+// hands them out one at a time.
+type MapPool struct {
+	maps          chan *mapnik.Map
+	width, height uint32
+}
+
+// NewMapPool loads n maps from stylesheetFile at the given tile size and
+// returns a pool ready to check them out.
+func NewMapPool(n int, stylesheetFile string, width, height uint32) (*MapPool, error) {
+	p := &MapPool{maps: make(chan *mapnik.Map, n), width: width, height: height}
+	for i := 0; i < n; i++ {
+		m := mapnik.NewMap(width, height)
+		if err := m.Load(stylesheetFile); err != nil {
+			close(p.maps)
+			for leaked := range p.maps {
+				leaked.Free()
+			}
+			m.Free()
+			return nil, err
+		}
+		p.maps <- m
+	}
+	return p, nil
+}
+
+// Size returns the tile dimensions maps in the pool are loaded at.
+func (p *MapPool) Size() (width, height uint32) {
+	return p.width, p.height
+}
+
+// Acquire checks out a map for exclusive use. Release must be called when
+// done with it.
+func (p *MapPool) Acquire() *mapnik.Map {
+	return <-p.maps
+}
+
+// Release returns a map checked out via Acquire back to the pool.
+func (p *MapPool) Release(m *mapnik.Map) {
+	p.maps <- m
+}
+
+// Handler serves XYZ tiles (Google/OSM y-origin at the top) at
+// /{z}/{x}/{y}.{format}, plus a /{z}/{x}/{y}@2x.{format} retina route that
+// renders at ScaleFactor=2 and double the pixel dimensions.
+type Handler struct {
+	Pool  Pool
+	Cache Cache
+	// StyleKey identifies the stylesheet the Pool's maps were loaded from
+	// (e.g. its path plus a modification time or content hash) and is
+	// folded into every CacheKey, so a Cache shared across differently
+	// configured servers or stylesheet reloads never serves stale tiles.
+	StyleKey string
+	// BufferSize, if non-zero, is applied to every map before rendering so
+	// that labels and symbols near a tile's edge aren't clipped.
+	BufferSize int
+}
+
+// Pool is the subset of *MapPool that Handler depends on.
+type Pool interface {
+	Acquire() *mapnik.Map
+	Release(m *mapnik.Map)
+	Size() (width, height uint32)
+}
+
+var tilePattern = regexp.MustCompile(`^/(\d+)/(\d+)/(\d+)(@2x)?\.(\w+)$`)
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	match := tilePattern.FindStringSubmatch(r.URL.Path)
+	if match == nil {
+		http.NotFound(w, r)
+		return
+	}
+	z, _ := strconv.Atoi(match[1])
+	x, _ := strconv.Atoi(match[2])
+	y, _ := strconv.Atoi(match[3])
+	scaleFactor := 1.0
+	if match[4] == "@2x" {
+		scaleFactor = 2.0
+	}
+	format := match[5]
+
+	key := CacheKey(h.StyleKey, x, y, z, scaleFactor, format)
+	if h.Cache != nil {
+		if data, ok := h.Cache.Get(key); ok {
+			writeTile(w, format, data)
+			return
+		}
+	}
+
+	m := h.Pool.Acquire()
+	defer h.Pool.Release(m)
+
+	// ScaleFactor only enlarges symbolizers (labels, line widths, ...); the
+	// output image keeps the map's configured pixel size unless we resize
+	// it too, so an @2x request must render into a size*scaleFactor canvas.
+	if scaleFactor != 1.0 {
+		baseWidth, baseHeight := h.Pool.Size()
+		m.Resize(uint32(float64(baseWidth)*scaleFactor), uint32(float64(baseHeight)*scaleFactor))
+		defer m.Resize(baseWidth, baseHeight)
+	}
+
+	if h.BufferSize != 0 {
+		m.SetBufferSize(h.BufferSize)
+	}
+	minx, miny, maxx, maxy := mapnik.XYZToMerc(x, y, z)
+	m.ZoomToMinMax(minx, miny, maxx, maxy)
+
+	data, err := m.Render(mapnik.RenderOpts{ScaleFactor: scaleFactor, Format: format})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if h.Cache != nil {
+		h.Cache.Set(key, data)
+	}
+	writeTile(w, format, data)
+}
+
+func writeTile(w http.ResponseWriter, format string, data []byte) {
+	w.Header().Set("Content-Type", contentType(format))
+	w.Write(data)
+}
+
+func contentType(format string) string {
+	switch format {
+	case "png", "png8", "png256":
+		return "image/png"
+	case "jpeg", "jpeg80":
+		return "image/jpeg"
+	case "webp":
+		return "image/webp"
+	default:
+		return "application/octet-stream"
+	}
+}